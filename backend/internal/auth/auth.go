@@ -0,0 +1,135 @@
+// Package auth implements password hashing and JWT issuance/validation for
+// the users subsystem, plus the AuthRequired Fiber middleware that protects
+// routes behind a valid access token.
+package auth
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 7 * 24 * time.Hour
+
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+)
+
+// Claims are the JWT claims issued for both access and refresh tokens.
+type Claims struct {
+	UserID int    `json:"user_id"`
+	Type   string `json:"type"`
+	jwt.RegisteredClaims
+}
+
+// HashPassword bcrypt-hashes a plaintext password for storage.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches the stored bcrypt hash.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// IssueTokenPair returns a fresh access and refresh token for userID, both
+// signed with secret using HS256.
+func IssueTokenPair(secret string, userID int) (accessToken, refreshToken string, err error) {
+	accessToken, err = sign(secret, userID, accessTokenType, accessTokenTTL)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, err = sign(secret, userID, refreshTokenType, refreshTokenTTL)
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+func sign(secret string, userID int, tokenType string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID: userID,
+		Type:   tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+// Parse validates tokenString and returns its claims if it is well-formed,
+// correctly signed with secret, and not expired.
+func Parse(secret, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// IsRefreshToken reports whether claims were issued for token refresh.
+func (c *Claims) IsRefreshToken() bool {
+	return c.Type == refreshTokenType
+}
+
+// IsAccessToken reports whether claims were issued for request authentication.
+func (c *Claims) IsAccessToken() bool {
+	return c.Type == accessTokenType
+}
+
+// AuthRequired is Fiber middleware that validates the
+// `Authorization: Bearer <jwt>` header using secret, rejecting missing,
+// malformed, expired, or non-access tokens with 401. On success it stores
+// the authenticated user id in c.Locals("user_id").
+func AuthRequired(secret string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(fiber.HeaderAuthorization)
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Missing or malformed Authorization header",
+			})
+		}
+
+		claims, err := Parse(secret, token)
+		if err != nil || !claims.IsAccessToken() {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid or expired token",
+			})
+		}
+
+		c.Locals("user_id", claims.UserID)
+		return c.Next()
+	}
+}
+
+// UserID reads the authenticated user id stashed by AuthRequired.
+func UserID(c *fiber.Ctx) int {
+	id, _ := c.Locals("user_id").(int)
+	return id
+}
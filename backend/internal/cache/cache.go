@@ -0,0 +1,125 @@
+// Package cache provides a thin, degrade-safe wrapper around Redis used as
+// a read-through cache in front of Postgres.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Client wraps a Redis connection. It never returns errors to callers:
+// a failed Redis operation is logged and treated as a cache miss so the
+// caller always falls back to querying the database directly.
+type Client struct {
+	rdb     *redis.Client
+	ttl     time.Duration
+	healthy atomic.Bool
+}
+
+// New connects to Redis at addr and returns a Client. Connectivity is
+// checked with a single ping; if it fails, the client starts in degraded
+// mode rather than returning an error, since the cache is optional.
+func New(addr, password string, db int, ttl time.Duration) *Client {
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+
+	c := &Client{rdb: rdb, ttl: ttl}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		log.Printf("Warning: Redis unreachable at %s, starting in degraded mode: %v", addr, err)
+		c.healthy.Store(false)
+	} else {
+		c.healthy.Store(true)
+	}
+
+	return c
+}
+
+// Healthy reports whether the most recent Redis operation succeeded.
+func (c *Client) Healthy() bool {
+	return c != nil && c.healthy.Load()
+}
+
+// Redis exposes the underlying go-redis client so other subsystems (e.g.
+// realtime Pub/Sub) can share this connection instead of opening their own.
+func (c *Client) Redis() *redis.Client {
+	if c == nil {
+		return nil
+	}
+	return c.rdb
+}
+
+// GetJSON looks up key and unmarshals it into dest. It returns false on a
+// cache miss, a Redis error, or a malformed value, in which case the
+// caller should fall back to the database.
+func (c *Client) GetJSON(ctx context.Context, key string, dest interface{}) bool {
+	if c == nil {
+		return false
+	}
+
+	val, err := c.rdb.Get(ctx, key).Result()
+	if err != nil {
+		if err != redis.Nil {
+			log.Printf("Warning: Redis GET %s failed: %v", key, err)
+			c.healthy.Store(false)
+		} else {
+			c.healthy.Store(true)
+		}
+		return false
+	}
+	c.healthy.Store(true)
+
+	if err := json.Unmarshal([]byte(val), dest); err != nil {
+		log.Printf("Warning: cached value for %s is not valid JSON: %v", key, err)
+		return false
+	}
+
+	return true
+}
+
+// SetJSON marshals v and stores it under key with the configured TTL.
+// Failures are logged and swallowed since caching is best-effort.
+func (c *Client) SetJSON(ctx context.Context, key string, v interface{}) {
+	if c == nil {
+		return
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Warning: failed to marshal value for cache key %s: %v", key, err)
+		return
+	}
+
+	if err := c.rdb.Set(ctx, key, data, c.ttl).Err(); err != nil {
+		log.Printf("Warning: Redis SET %s failed: %v", key, err)
+		c.healthy.Store(false)
+		return
+	}
+	c.healthy.Store(true)
+}
+
+// Del removes the given keys from the cache. Failures are logged and
+// swallowed; a stale entry will simply expire via its TTL.
+func (c *Client) Del(ctx context.Context, keys ...string) {
+	if c == nil || len(keys) == 0 {
+		return
+	}
+
+	if err := c.rdb.Del(ctx, keys...).Err(); err != nil {
+		log.Printf("Warning: Redis DEL %v failed: %v", keys, err)
+		c.healthy.Store(false)
+		return
+	}
+	c.healthy.Store(true)
+}
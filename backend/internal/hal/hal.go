@@ -0,0 +1,42 @@
+// Package hal builds minimal HAL+JSON (application/hal+json) envelopes so
+// Fiber handlers can opt into hypermedia responses alongside plain JSON.
+package hal
+
+import "encoding/json"
+
+// Link is a single HAL hyperlink.
+type Link struct {
+	Href string `json:"href"`
+}
+
+// Links is a named set of HAL links, keyed by relation (e.g. "self", "next").
+type Links map[string]Link
+
+// Embedded holds the `_embedded` resources of a Collection, keyed by
+// relation name (e.g. "items").
+type Embedded map[string]interface{}
+
+// Collection is a HAL list response: embedded resources plus collection-level
+// links and a total item count.
+type Collection struct {
+	Links    Links    `json:"_links"`
+	Embedded Embedded `json:"_embedded"`
+	Count    int      `json:"count"`
+}
+
+// ToResource marshals v to JSON and merges a `_links` member into the
+// resulting object, turning a single domain object into a HAL resource.
+func ToResource(v interface{}, links Links) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	m["_links"] = links
+	return m, nil
+}
@@ -0,0 +1,113 @@
+// Package httpcache implements conditional GET support (Last-Modified /
+// If-Modified-Since, weak ETag / If-None-Match) backed by in-memory
+// timestamps that handlers bump whenever a resource changes.
+package httpcache
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Tracker records the last-modified time of a collection and of each of its
+// items. Collections and items are addressed by a caller-chosen scope (e.g.
+// a user id), so a single Tracker can serve several independent owners
+// without their timestamps leaking into one another. It is safe for
+// concurrent use.
+type Tracker struct {
+	mu          sync.RWMutex
+	collections map[string]time.Time
+	items       map[string]time.Time
+}
+
+// NewTracker returns an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{
+		collections: make(map[string]time.Time),
+		items:       make(map[string]time.Time),
+	}
+}
+
+func itemKey(scope, id string) string {
+	return scope + ":" + id
+}
+
+// TouchCollection marks the collection in scope as modified now.
+func (t *Tracker) TouchCollection(scope string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.collections[scope] = time.Now().Truncate(time.Second)
+}
+
+// TouchItem marks both id and its collection (within scope) as modified now.
+func (t *Tracker) TouchItem(scope, id string) {
+	now := time.Now().Truncate(time.Second)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.collections[scope] = now
+	t.items[itemKey(scope, id)] = now
+}
+
+// DeleteItem forgets id's timestamp and bumps its collection, so a
+// subsequent GET of the deleted id is no longer considered unmodified.
+func (t *Tracker) DeleteItem(scope, id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.collections[scope] = time.Now().Truncate(time.Second)
+	delete(t.items, itemKey(scope, id))
+}
+
+// Collection returns the last-modified time of the collection in scope.
+func (t *Tracker) Collection(scope string) time.Time {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.collections[scope]
+}
+
+// Item returns the last-modified time of id within scope, or the zero time
+// if it has never been touched.
+func (t *Tracker) Item(scope, id string) time.Time {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.items[itemKey(scope, id)]
+}
+
+// Conditional returns Fiber middleware that answers GET requests with
+// 304 Not Modified when lastModified(c) has not advanced past the
+// client's If-Modified-Since/If-None-Match headers, and otherwise sets
+// Last-Modified/ETag on the response before calling c.Next().
+func Conditional(lastModified func(c *fiber.Ctx) time.Time) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodGet {
+			return c.Next()
+		}
+
+		lm := lastModified(c)
+		if lm.IsZero() {
+			return c.Next()
+		}
+
+		etag := fmt.Sprintf(`W/"%d"`, lm.Unix())
+		notModified := false
+
+		if inm := c.Get(fiber.HeaderIfNoneMatch); inm != "" {
+			notModified = inm == etag
+		} else if ims := c.Get(fiber.HeaderIfModifiedSince); ims != "" {
+			if t, err := http.ParseTime(ims); err == nil {
+				notModified = !lm.After(t)
+			}
+		}
+
+		c.Set(fiber.HeaderLastModified, lm.UTC().Format(http.TimeFormat))
+		c.Set(fiber.HeaderETag, etag)
+
+		if notModified {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+
+		return c.Next()
+	}
+}
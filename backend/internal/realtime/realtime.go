@@ -0,0 +1,167 @@
+// Package realtime fans out item mutation events to WebSocket subscribers,
+// either purely in-process or via a shared Redis Pub/Sub channel so that
+// multiple backend instances broadcast the same stream.
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisChannel     = "items:events"
+	clientBufferSize = 16
+)
+
+// Event is the envelope published on every successful item mutation.
+// UserID is the owning user's id and is never serialized to clients; it
+// exists only so Hub can scope delivery to that user's own connections.
+type Event struct {
+	Object string      `json:"object"`
+	Action string      `json:"action"`
+	Data   interface{} `json:"data"`
+	Source string      `json:"source"`
+	UserID int         `json:"-"`
+}
+
+type client struct {
+	conn   *websocket.Conn
+	send   chan Event
+	source string
+	userID int
+}
+
+// Hub fans events out to connected WebSocket clients. A client whose
+// recorded source matches an event's Source is skipped, so the originator
+// of a mutating HTTP request doesn't double-apply its own change.
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[*client]struct{}
+
+	rdb *redis.Client
+}
+
+// New returns a Hub. If rdb is non-nil, published events are also sent to
+// and received from Redis Pub/Sub on "items:events", so the stream is
+// shared across backend instances; if rdb is nil, fan-out is purely
+// in-process.
+func New(rdb *redis.Client) *Hub {
+	h := &Hub{clients: make(map[*client]struct{}), rdb: rdb}
+	if rdb != nil {
+		go h.subscribeRedis()
+	}
+	return h
+}
+
+// Register adds conn as a subscriber scoped to userID, under the given
+// source identifier, and blocks until the connection closes. It only ever
+// delivers events owned by userID.
+func (h *Hub) Register(conn *websocket.Conn, userID int, source string) {
+	cl := &client{conn: conn, send: make(chan Event, clientBufferSize), source: source, userID: userID}
+
+	h.mu.Lock()
+	h.clients[cl] = struct{}{}
+	h.mu.Unlock()
+
+	defer h.disconnect(cl)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// Discard inbound messages/control frames; this pump's only job is
+		// to detect a closed connection so disconnect runs promptly.
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case event, ok := <-cl.send:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// Publish fans event out to local subscribers and, if a Redis backend is
+// configured, publishes it for other instances' subscribers too.
+func (h *Hub) Publish(ctx context.Context, event Event) {
+	h.broadcastLocal(event)
+
+	if h.rdb == nil {
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Warning: failed to marshal realtime event: %v", err)
+		return
+	}
+	if err := h.rdb.Publish(ctx, redisChannel, data).Err(); err != nil {
+		log.Printf("Warning: failed to publish realtime event to Redis: %v", err)
+	}
+}
+
+func (h *Hub) broadcastLocal(event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for cl := range h.clients {
+		if cl.userID != event.UserID {
+			continue
+		}
+		if cl.source != "" && cl.source == event.Source {
+			continue
+		}
+
+		select {
+		case cl.send <- event:
+		default:
+			log.Printf("Warning: disconnecting slow realtime subscriber")
+			go h.disconnect(cl)
+		}
+	}
+}
+
+// disconnect removes cl from the client set, closing its send channel and
+// connection. It is safe to call more than once for the same client.
+func (h *Hub) disconnect(cl *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[cl]; !ok {
+		return
+	}
+	delete(h.clients, cl)
+	close(cl.send)
+	cl.conn.Close()
+}
+
+func (h *Hub) subscribeRedis() {
+	ctx := context.Background()
+	sub := h.rdb.Subscribe(ctx, redisChannel)
+	defer sub.Close()
+
+	for msg := range sub.Channel() {
+		var event Event
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			log.Printf("Warning: received malformed realtime event: %v", err)
+			continue
+		}
+		h.broadcastLocal(event)
+	}
+}
@@ -0,0 +1,57 @@
+// Package validate wraps go-playground/validator to turn struct validation
+// failures into structured, field-level errors for JSON error responses.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var v = validator.New()
+
+func init() {
+	// Report the JSON tag name instead of the Go field name, so field errors
+	// match the names in the request body.
+	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+}
+
+// FieldError describes a single failed validation rule on one field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Struct validates s against its `validate` struct tags and returns one
+// FieldError per failed rule, or nil if s is valid.
+func Struct(s interface{}) []FieldError {
+	err := v.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []FieldError{{Message: err.Error()}}
+	}
+
+	fieldErrs := make([]FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		fieldErrs = append(fieldErrs, FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: fmt.Sprintf("%s failed validation: %s", fe.Field(), fe.Tag()),
+		})
+	}
+
+	return fieldErrs
+}
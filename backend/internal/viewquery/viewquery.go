@@ -0,0 +1,88 @@
+// Package viewquery translates a saved view's filters into a parameterised
+// SQL WHERE clause against the items table, using a strict allowlist of
+// fields and operators so filter input can never reach SQL unescaped.
+package viewquery
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter is one stored condition to translate into SQL.
+type Filter struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// fieldOps allowlists, per field, which operators are sane for that
+// field's type — e.g. ILIKE on a timestamp column is a SQL error, not just
+// a style nit, so "contains" is only offered on text fields.
+var fieldOps = map[string]map[string]bool{
+	"title":       {"eq": true, "neq": true, "contains": true},
+	"description": {"eq": true, "neq": true, "contains": true},
+	"created_at":  {"eq": true, "neq": true, "lt": true, "gt": true, "before": true, "after": true},
+	"updated_at":  {"eq": true, "neq": true, "lt": true, "gt": true, "before": true, "after": true},
+}
+
+var opSQL = map[string]string{
+	"eq":       "=",
+	"neq":      "!=",
+	"contains": "ILIKE",
+	"lt":       "<",
+	"gt":       ">",
+	"before":   "<",
+	"after":    ">",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Valid reports whether op is recognized and sane for field, so callers can
+// reject an unsupported filter or field/op combination at creation time
+// instead of only failing later when the view is queried.
+func Valid(field, op string) bool {
+	return fieldOps[field][op]
+}
+
+// Build returns a SQL WHERE fragment (without the leading "WHERE") ANDing
+// together filters, plus its positional arguments with placeholders
+// starting at argOffset+1. It returns an error if any filter names a field
+// or op outside the allowlist, so callers never interpolate raw filter
+// input into SQL.
+func Build(filters []Filter, argOffset int) (string, []interface{}, error) {
+	if len(filters) == 0 {
+		return "", nil, nil
+	}
+
+	clauses := make([]string, 0, len(filters))
+	args := make([]interface{}, 0, len(filters))
+
+	for _, f := range filters {
+		ops, ok := fieldOps[f.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("field %q is not filterable", f.Field)
+		}
+
+		if !ops[f.Op] {
+			return "", nil, fmt.Errorf("operator %q is not supported on field %q", f.Op, f.Field)
+		}
+
+		op := opSQL[f.Op]
+
+		value := f.Value
+		if f.Op == "contains" {
+			value = "%" + likeEscaper.Replace(value) + "%"
+		}
+
+		argOffset++
+		clauses = append(clauses, fmt.Sprintf("%s %s $%d", f.Field, op, argOffset))
+		args = append(args, value)
+	}
+
+	where := clauses[0]
+	for _, clause := range clauses[1:] {
+		where += " AND " + clause
+	}
+
+	return where, args, nil
+}
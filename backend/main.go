@@ -5,17 +5,50 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
+
+	"neevs/internal/auth"
+	"neevs/internal/cache"
+	"neevs/internal/hal"
+	"neevs/internal/httpcache"
+	"neevs/internal/realtime"
+	"neevs/internal/validate"
+	"neevs/internal/viewquery"
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
 )
 
+func userScope(userID int) string {
+	return strconv.Itoa(userID)
+}
+
+func allItemsCacheKey(userID int) string {
+	return fmt.Sprintf("allitems:user:%d", userID)
+}
+
+func itemCacheKey(userID int, id string) string {
+	return fmt.Sprintf("item:%s:user:%d", id, userID)
+}
+
 var db *sql.DB
+var rdb *cache.Client
+var itemsTracker = httpcache.NewTracker()
+var jwtSecret string
+var hub *realtime.Hub
 
 // Models
 type Item struct {
@@ -27,16 +60,84 @@ type Item struct {
 }
 
 type CreateItemRequest struct {
-	Title       string `json:"title"`
+	Title       string `json:"title" validate:"required"`
 	Description string `json:"description"`
 }
 
 type HealthResponse struct {
 	Status   string `json:"status"`
 	Database string `json:"database"`
+	Cache    string `json:"cache"`
 	Time     string `json:"time"`
 }
 
+// User is the persisted account record. PasswordHash never leaves the
+// server; handlers always respond with a UserRes instead.
+type User struct {
+	ID           int       `json:"id"`
+	Email        string    `json:"email"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// UserReq is the request DTO for registration and login.
+type UserReq struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// UserRes is the response DTO for a user; it never includes PasswordHash.
+type UserRes struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toUserRes(u User) UserRes {
+	return UserRes{ID: u.ID, Email: u.Email, CreatedAt: u.CreatedAt}
+}
+
+// AuthResponse is returned by register/login/refresh.
+type AuthResponse struct {
+	AccessToken  string  `json:"accessToken"`
+	RefreshToken string  `json:"refreshToken"`
+	User         UserRes `json:"user"`
+}
+
+// RefreshRequest is the request DTO for POST /api/auth/refresh.
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+}
+
+// View is a saved, named set of filters a user can reapply to their items.
+type View struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	OwnerUserID int       `json:"owner_user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ViewReq is the request DTO for creating/updating a View.
+type ViewReq struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// Filter is one condition belonging to a View.
+type Filter struct {
+	ID     int    `json:"id"`
+	ViewID int    `json:"view_id"`
+	Field  string `json:"field"`
+	Op     string `json:"op"`
+	Value  string `json:"value"`
+}
+
+// FilterReq is the request DTO for creating/updating a Filter.
+type FilterReq struct {
+	Field string `json:"field" validate:"required"`
+	Op    string `json:"op" validate:"required"`
+	Value string `json:"value" validate:"required"`
+}
+
 // Database initialization
 func initDB() error {
 	var err error
@@ -72,20 +173,121 @@ func initDB() error {
 	return nil
 }
 
+// initCache connects to Redis. Unlike initDB, a connection failure here is
+// not fatal: the cache degrades to always-miss and handlers fall back to
+// querying Postgres directly.
+func initCache() {
+	ttlSeconds, err := strconv.Atoi(getEnv("CACHE_TTL_SECONDS", "30"))
+	if err != nil || ttlSeconds <= 0 {
+		ttlSeconds = 30
+	}
+
+	redisDB, err := strconv.Atoi(getEnv("REDIS_DB", "0"))
+	if err != nil {
+		redisDB = 0
+	}
+
+	rdb = cache.New(
+		getEnv("REDIS_ADDR", "localhost:6379"),
+		getEnv("REDIS_PASSWORD", ""),
+		redisDB,
+		time.Duration(ttlSeconds)*time.Second,
+	)
+}
+
+// initRealtime wires up the WebSocket fan-out hub. With REALTIME_BACKEND=redis
+// it shares the cache's Redis connection for Pub/Sub so multiple backend
+// instances broadcast the same event stream; otherwise it stays in-process.
+func initRealtime() {
+	if getEnv("REALTIME_BACKEND", "memory") == "redis" {
+		hub = realtime.New(rdb.Redis())
+		return
+	}
+	hub = realtime.New(nil)
+}
+
 func createTables() error {
 	query := `
+	CREATE TABLE IF NOT EXISTS users (
+		id SERIAL PRIMARY KEY,
+		email VARCHAR(255) UNIQUE NOT NULL,
+		password_hash VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+
 	CREATE TABLE IF NOT EXISTS items (
 		id SERIAL PRIMARY KEY,
+		user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
 		title VARCHAR(255) NOT NULL,
 		description TEXT,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	);
 
+	ALTER TABLE items ADD COLUMN IF NOT EXISTS user_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+
 	CREATE INDEX IF NOT EXISTS idx_items_created_at ON items(created_at DESC);
+	CREATE INDEX IF NOT EXISTS idx_items_user_id ON items(user_id);
+
+	CREATE TABLE IF NOT EXISTS views (
+		id SERIAL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		owner_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS filters (
+		id SERIAL PRIMARY KEY,
+		view_id INTEGER NOT NULL REFERENCES views(id) ON DELETE CASCADE,
+		field VARCHAR(50) NOT NULL,
+		op VARCHAR(20) NOT NULL,
+		value TEXT NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_views_owner_user_id ON views(owner_user_id);
+	CREATE INDEX IF NOT EXISTS idx_filters_view_id ON filters(view_id);
 	`
 
-	_, err := db.Exec(query)
+	if _, err := db.Exec(query); err != nil {
+		return err
+	}
+
+	if err := backfillOrphanedItems(); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(`ALTER TABLE items ALTER COLUMN user_id SET NOT NULL`)
+	return err
+}
+
+// backfillOrphanedItems assigns any item left over from before items were
+// user-owned (user_id NULL, e.g. a database that predates chunk0-4) to a
+// dedicated, non-loginable system user, so those rows stay reachable
+// through the API instead of becoming permanently invisible once every
+// handler scopes by user_id. It is a no-op once every item already has an
+// owner, which is the common case.
+func backfillOrphanedItems() error {
+	var orphaned int
+	if err := db.QueryRow(`SELECT count(*) FROM items WHERE user_id IS NULL`).Scan(&orphaned); err != nil {
+		return err
+	}
+	if orphaned == 0 {
+		return nil
+	}
+
+	const systemEmail = "migrated-items@system.local"
+	var systemUserID int
+	err := db.QueryRow(`
+		INSERT INTO users (email, password_hash)
+		VALUES ($1, '')
+		ON CONFLICT (email) DO UPDATE SET email = EXCLUDED.email
+		RETURNING id
+	`, systemEmail).Scan(&systemUserID)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`UPDATE items SET user_id = $1 WHERE user_id IS NULL`, systemUserID)
 	return err
 }
 
@@ -96,89 +298,218 @@ func healthCheck(c *fiber.Ctx) error {
 		dbStatus = "disconnected"
 	}
 
+	cacheStatus := "disabled"
+	if rdb != nil {
+		cacheStatus = "disconnected"
+		if rdb.Healthy() {
+			cacheStatus = "connected"
+		}
+	}
+
 	return c.JSON(HealthResponse{
 		Status:   "ok",
 		Database: dbStatus,
+		Cache:    cacheStatus,
 		Time:     time.Now().Format(time.RFC3339),
 	})
 }
 
+// wantsHAL reports whether the client asked for a HAL+JSON response.
+// Plain JSON remains the default for backwards compatibility.
+func wantsHAL(c *fiber.Ctx) bool {
+	return strings.Contains(c.Get("Accept"), "application/hal+json")
+}
+
+// paginationParams reads ?page= and ?per_page=, defaulting to page 1 and
+// 20 per page, and clamps per_page to maxPerPage.
+func paginationParams(c *fiber.Ctx) (int, int) {
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(c.Query("per_page", strconv.Itoa(defaultPerPage)))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	return page, perPage
+}
+
 func getItems(c *fiber.Ctx) error {
-	rows, err := db.Query(`
-		SELECT id, title, description, created_at, updated_at
-		FROM items
-		ORDER BY created_at DESC
-		LIMIT 100
-	`)
-	if err != nil {
-		log.Printf("Error querying items: %v", err)
+	userID := auth.UserID(c)
+	page, perPage := paginationParams(c)
+	offset := (page - 1) * perPage
+
+	// Only the default first page is cached, so a single per-user key
+	// can be invalidated on write without tracking every page variant.
+	useCache := page == 1 && perPage == defaultPerPage
+
+	var items []Item
+	cached := useCache && rdb.GetJSON(c.Context(), allItemsCacheKey(userID), &items)
+	if !cached {
+		rows, err := db.Query(`
+			SELECT id, title, description, created_at, updated_at
+			FROM items
+			WHERE user_id = $1
+			ORDER BY created_at DESC
+			LIMIT $2 OFFSET $3
+		`, userID, perPage, offset)
+		if err != nil {
+			log.Printf("Error querying items: %v", err)
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Failed to fetch items",
+			})
+		}
+		defer rows.Close()
+
+		items = []Item{}
+		for rows.Next() {
+			var item Item
+			err := rows.Scan(&item.ID, &item.Title, &item.Description, &item.CreatedAt, &item.UpdatedAt)
+			if err != nil {
+				log.Printf("Error scanning item: %v", err)
+				continue
+			}
+			items = append(items, item)
+		}
+
+		if useCache {
+			rdb.SetJSON(c.Context(), allItemsCacheKey(userID), items)
+		}
+	}
+
+	if !wantsHAL(c) {
+		return c.JSON(items)
+	}
+
+	var total int
+	if err := db.QueryRow("SELECT COUNT(*) FROM items WHERE user_id = $1", userID).Scan(&total); err != nil {
+		log.Printf("Error counting items: %v", err)
 		return c.Status(500).JSON(fiber.Map{
 			"error": "Failed to fetch items",
 		})
 	}
-	defer rows.Close()
 
-	items := []Item{}
-	for rows.Next() {
-		var item Item
-		err := rows.Scan(&item.ID, &item.Title, &item.Description, &item.CreatedAt, &item.UpdatedAt)
+	return c.JSON(buildItemsCollection(items, "/api/items", page, perPage, total))
+}
+
+// buildItemsCollection wraps items in a HAL collection with pagination
+// links, rooted at basePath, derived from page, perPage and the total row
+// count.
+func buildItemsCollection(items []Item, basePath string, page, perPage, total int) hal.Collection {
+	embedded := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		res, err := hal.ToResource(item, hal.Links{
+			"self":       {Href: fmt.Sprintf("/api/items/%d", item.ID)},
+			"collection": {Href: "/api/items"},
+		})
 		if err != nil {
-			log.Printf("Error scanning item: %v", err)
+			log.Printf("Error building HAL resource for item %d: %v", item.ID, err)
 			continue
 		}
-		items = append(items, item)
+		embedded = append(embedded, res)
+	}
+
+	lastPage := int(math.Ceil(float64(total) / float64(perPage)))
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	pageLink := func(p int) hal.Link {
+		return hal.Link{Href: fmt.Sprintf("%s?page=%d&per_page=%d", basePath, p, perPage)}
 	}
 
-	return c.JSON(items)
+	links := hal.Links{
+		"self":  pageLink(page),
+		"first": pageLink(1),
+		"last":  pageLink(lastPage),
+	}
+	if page > 1 {
+		links["prev"] = pageLink(page - 1)
+	}
+	if page < lastPage {
+		links["next"] = pageLink(page + 1)
+	}
+
+	return hal.Collection{
+		Links:    links,
+		Embedded: hal.Embedded{"items": embedded},
+		Count:    total,
+	}
 }
 
 func getItem(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
 	id := c.Params("id")
 
 	var item Item
-	err := db.QueryRow(`
-		SELECT id, title, description, created_at, updated_at
-		FROM items
-		WHERE id = $1
-	`, id).Scan(&item.ID, &item.Title, &item.Description, &item.CreatedAt, &item.UpdatedAt)
+	if !rdb.GetJSON(c.Context(), itemCacheKey(userID, id), &item) {
+		err := db.QueryRow(`
+			SELECT id, title, description, created_at, updated_at
+			FROM items
+			WHERE id = $1 AND user_id = $2
+		`, id, userID).Scan(&item.ID, &item.Title, &item.Description, &item.CreatedAt, &item.UpdatedAt)
 
-	if err == sql.ErrNoRows {
-		return c.Status(404).JSON(fiber.Map{
-			"error": "Item not found",
-		})
+		if err == sql.ErrNoRows {
+			return c.Status(404).JSON(fiber.Map{
+				"error": "Item not found",
+			})
+		}
+
+		if err != nil {
+			log.Printf("Error fetching item: %v", err)
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Failed to fetch item",
+			})
+		}
+
+		rdb.SetJSON(c.Context(), itemCacheKey(userID, id), item)
+	}
+
+	if !wantsHAL(c) {
+		return c.JSON(item)
 	}
 
+	res, err := hal.ToResource(item, hal.Links{
+		"self":       {Href: fmt.Sprintf("/api/items/%d", item.ID)},
+		"collection": {Href: "/api/items"},
+	})
 	if err != nil {
-		log.Printf("Error fetching item: %v", err)
+		log.Printf("Error building HAL resource for item %d: %v", item.ID, err)
 		return c.Status(500).JSON(fiber.Map{
 			"error": "Failed to fetch item",
 		})
 	}
 
-	return c.JSON(item)
+	return c.JSON(res)
 }
 
 func createItem(c *fiber.Ctx) error {
-	var req CreateItemRequest
+	userID := auth.UserID(c)
 
+	var req CreateItemRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(400).JSON(fiber.Map{
 			"error": "Invalid request body",
 		})
 	}
 
-	if req.Title == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Title is required",
+	if fieldErrs := validate.Struct(req); fieldErrs != nil {
+		return c.Status(422).JSON(fiber.Map{
+			"errors": fieldErrs,
 		})
 	}
 
 	var item Item
 	err := db.QueryRow(`
-		INSERT INTO items (title, description)
-		VALUES ($1, $2)
+		INSERT INTO items (user_id, title, description)
+		VALUES ($1, $2, $3)
 		RETURNING id, title, description, created_at, updated_at
-	`, req.Title, req.Description).Scan(&item.ID, &item.Title, &item.Description, &item.CreatedAt, &item.UpdatedAt)
+	`, userID, req.Title, req.Description).Scan(&item.ID, &item.Title, &item.Description, &item.CreatedAt, &item.UpdatedAt)
 
 	if err != nil {
 		log.Printf("Error creating item: %v", err)
@@ -187,10 +518,21 @@ func createItem(c *fiber.Ctx) error {
 		})
 	}
 
+	rdb.Del(c.Context(), allItemsCacheKey(userID))
+	itemsTracker.TouchItem(userScope(userID), strconv.Itoa(item.ID))
+	hub.Publish(c.Context(), realtime.Event{
+		Object: "item",
+		Action: "create",
+		Data:   item,
+		Source: c.Get("X-Request-Source"),
+		UserID: userID,
+	})
+
 	return c.Status(201).JSON(item)
 }
 
 func updateItem(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
 	id := c.Params("id")
 
 	var req CreateItemRequest
@@ -200,13 +542,19 @@ func updateItem(c *fiber.Ctx) error {
 		})
 	}
 
+	if fieldErrs := validate.Struct(req); fieldErrs != nil {
+		return c.Status(422).JSON(fiber.Map{
+			"errors": fieldErrs,
+		})
+	}
+
 	var item Item
 	err := db.QueryRow(`
 		UPDATE items
 		SET title = $1, description = $2, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $3
+		WHERE id = $3 AND user_id = $4
 		RETURNING id, title, description, created_at, updated_at
-	`, req.Title, req.Description, id).Scan(&item.ID, &item.Title, &item.Description, &item.CreatedAt, &item.UpdatedAt)
+	`, req.Title, req.Description, id, userID).Scan(&item.ID, &item.Title, &item.Description, &item.CreatedAt, &item.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		return c.Status(404).JSON(fiber.Map{
@@ -221,13 +569,24 @@ func updateItem(c *fiber.Ctx) error {
 		})
 	}
 
+	rdb.Del(c.Context(), allItemsCacheKey(userID), itemCacheKey(userID, id))
+	itemsTracker.TouchItem(userScope(userID), id)
+	hub.Publish(c.Context(), realtime.Event{
+		Object: "item",
+		Action: "update",
+		Data:   item,
+		Source: c.Get("X-Request-Source"),
+		UserID: userID,
+	})
+
 	return c.JSON(item)
 }
 
 func deleteItem(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
 	id := c.Params("id")
 
-	result, err := db.Exec("DELETE FROM items WHERE id = $1", id)
+	result, err := db.Exec("DELETE FROM items WHERE id = $1 AND user_id = $2", id, userID)
 	if err != nil {
 		log.Printf("Error deleting item: %v", err)
 		return c.Status(500).JSON(fiber.Map{
@@ -242,11 +601,632 @@ func deleteItem(c *fiber.Ctx) error {
 		})
 	}
 
+	rdb.Del(c.Context(), allItemsCacheKey(userID), itemCacheKey(userID, id))
+	itemsTracker.DeleteItem(userScope(userID), id)
+	hub.Publish(c.Context(), realtime.Event{
+		Object: "item",
+		Action: "delete",
+		Data:   fiber.Map{"id": id},
+		Source: c.Get("X-Request-Source"),
+		UserID: userID,
+	})
+
 	return c.JSON(fiber.Map{
 		"message": "Item deleted successfully",
 	})
 }
 
+// viewExists reports whether a view with the given id is owned by
+// ownerUserID, without relying on a foreign-key error to signal a missing
+// parent.
+func viewExists(id string, ownerUserID int) (bool, error) {
+	var exists bool
+	err := db.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM views WHERE id = $1 AND owner_user_id = $2)
+	`, id, ownerUserID).Scan(&exists)
+	return exists, err
+}
+
+func loadViewFilters(viewID string) ([]viewquery.Filter, error) {
+	rows, err := db.Query(`SELECT field, op, value FROM filters WHERE view_id = $1`, viewID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var filters []viewquery.Filter
+	for rows.Next() {
+		var f viewquery.Filter
+		if err := rows.Scan(&f.Field, &f.Op, &f.Value); err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	return filters, rows.Err()
+}
+
+func createView(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
+
+	var req ViewReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if fieldErrs := validate.Struct(req); fieldErrs != nil {
+		return c.Status(422).JSON(fiber.Map{
+			"errors": fieldErrs,
+		})
+	}
+
+	var view View
+	err := db.QueryRow(`
+		INSERT INTO views (name, owner_user_id)
+		VALUES ($1, $2)
+		RETURNING id, name, owner_user_id, created_at
+	`, req.Name, userID).Scan(&view.ID, &view.Name, &view.OwnerUserID, &view.CreatedAt)
+
+	if err != nil {
+		log.Printf("Error creating view: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to create view",
+		})
+	}
+
+	return c.Status(201).JSON(view)
+}
+
+func getViews(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
+
+	rows, err := db.Query(`
+		SELECT id, name, owner_user_id, created_at
+		FROM views
+		WHERE owner_user_id = $1
+		ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		log.Printf("Error querying views: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch views",
+		})
+	}
+	defer rows.Close()
+
+	views := []View{}
+	for rows.Next() {
+		var view View
+		if err := rows.Scan(&view.ID, &view.Name, &view.OwnerUserID, &view.CreatedAt); err != nil {
+			log.Printf("Error scanning view: %v", err)
+			continue
+		}
+		views = append(views, view)
+	}
+
+	return c.JSON(views)
+}
+
+func getView(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
+	id := c.Params("id")
+
+	var view View
+	err := db.QueryRow(`
+		SELECT id, name, owner_user_id, created_at
+		FROM views
+		WHERE id = $1 AND owner_user_id = $2
+	`, id, userID).Scan(&view.ID, &view.Name, &view.OwnerUserID, &view.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "View not found",
+		})
+	}
+
+	if err != nil {
+		log.Printf("Error fetching view: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch view",
+		})
+	}
+
+	return c.JSON(view)
+}
+
+func updateView(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
+	id := c.Params("id")
+
+	var req ViewReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if fieldErrs := validate.Struct(req); fieldErrs != nil {
+		return c.Status(422).JSON(fiber.Map{
+			"errors": fieldErrs,
+		})
+	}
+
+	var view View
+	err := db.QueryRow(`
+		UPDATE views
+		SET name = $1
+		WHERE id = $2 AND owner_user_id = $3
+		RETURNING id, name, owner_user_id, created_at
+	`, req.Name, id, userID).Scan(&view.ID, &view.Name, &view.OwnerUserID, &view.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "View not found",
+		})
+	}
+
+	if err != nil {
+		log.Printf("Error updating view: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to update view",
+		})
+	}
+
+	return c.JSON(view)
+}
+
+func deleteView(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
+	id := c.Params("id")
+
+	result, err := db.Exec("DELETE FROM views WHERE id = $1 AND owner_user_id = $2", id, userID)
+	if err != nil {
+		log.Printf("Error deleting view: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to delete view",
+		})
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "View not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "View deleted successfully",
+	})
+}
+
+// getViewItems applies a view's filters, translated by viewquery, to the
+// authenticated user's items, composed with the same pagination used by
+// the plain items list.
+func getViewItems(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
+	viewID := c.Params("id")
+
+	exists, err := viewExists(viewID, userID)
+	if err != nil {
+		log.Printf("Error checking view: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch view items",
+		})
+	}
+	if !exists {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "View not found",
+		})
+	}
+
+	filters, err := loadViewFilters(viewID)
+	if err != nil {
+		log.Printf("Error loading filters: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch view items",
+		})
+	}
+
+	where, whereArgs, err := viewquery.Build(filters, 1)
+	if err != nil {
+		log.Printf("Error building view filter query: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch view items",
+		})
+	}
+
+	condition := "user_id = $1"
+	if where != "" {
+		condition += " AND " + where
+	}
+	baseArgs := append([]interface{}{userID}, whereArgs...)
+
+	page, perPage := paginationParams(c)
+	offset := (page - 1) * perPage
+
+	listArgs := append(append([]interface{}{}, baseArgs...), perPage, offset)
+	rows, err := db.Query(fmt.Sprintf(`
+		SELECT id, title, description, created_at, updated_at
+		FROM items
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, condition, len(baseArgs)+1, len(baseArgs)+2), listArgs...)
+	if err != nil {
+		log.Printf("Error querying view items: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch view items",
+		})
+	}
+	defer rows.Close()
+
+	items := []Item{}
+	for rows.Next() {
+		var item Item
+		if err := rows.Scan(&item.ID, &item.Title, &item.Description, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			log.Printf("Error scanning item: %v", err)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	if !wantsHAL(c) {
+		return c.JSON(items)
+	}
+
+	var total int
+	if err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM items WHERE %s", condition), baseArgs...).Scan(&total); err != nil {
+		log.Printf("Error counting view items: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch view items",
+		})
+	}
+
+	return c.JSON(buildItemsCollection(items, fmt.Sprintf("/api/views/%s/items", viewID), page, perPage, total))
+}
+
+func createFilter(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
+	viewID := c.Params("id")
+
+	exists, err := viewExists(viewID, userID)
+	if err != nil {
+		log.Printf("Error checking view: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to create filter",
+		})
+	}
+	if !exists {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "View not found",
+		})
+	}
+
+	var req FilterReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if fieldErrs := validate.Struct(req); fieldErrs != nil {
+		return c.Status(422).JSON(fiber.Map{
+			"errors": fieldErrs,
+		})
+	}
+
+	if !viewquery.Valid(req.Field, req.Op) {
+		return c.Status(422).JSON(fiber.Map{
+			"error": "Unsupported filter field or operator",
+		})
+	}
+
+	var filter Filter
+	err = db.QueryRow(`
+		INSERT INTO filters (view_id, field, op, value)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, view_id, field, op, value
+	`, viewID, req.Field, req.Op, req.Value).Scan(&filter.ID, &filter.ViewID, &filter.Field, &filter.Op, &filter.Value)
+
+	if err != nil {
+		log.Printf("Error creating filter: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to create filter",
+		})
+	}
+
+	return c.Status(201).JSON(filter)
+}
+
+func getFilters(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
+	viewID := c.Params("id")
+
+	exists, err := viewExists(viewID, userID)
+	if err != nil {
+		log.Printf("Error checking view: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch filters",
+		})
+	}
+	if !exists {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "View not found",
+		})
+	}
+
+	rows, err := db.Query(`
+		SELECT id, view_id, field, op, value
+		FROM filters
+		WHERE view_id = $1
+		ORDER BY id
+	`, viewID)
+	if err != nil {
+		log.Printf("Error querying filters: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch filters",
+		})
+	}
+	defer rows.Close()
+
+	filters := []Filter{}
+	for rows.Next() {
+		var filter Filter
+		if err := rows.Scan(&filter.ID, &filter.ViewID, &filter.Field, &filter.Op, &filter.Value); err != nil {
+			log.Printf("Error scanning filter: %v", err)
+			continue
+		}
+		filters = append(filters, filter)
+	}
+
+	return c.JSON(filters)
+}
+
+func updateFilter(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
+	id := c.Params("id")
+
+	var req FilterReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if fieldErrs := validate.Struct(req); fieldErrs != nil {
+		return c.Status(422).JSON(fiber.Map{
+			"errors": fieldErrs,
+		})
+	}
+
+	if !viewquery.Valid(req.Field, req.Op) {
+		return c.Status(422).JSON(fiber.Map{
+			"error": "Unsupported filter field or operator",
+		})
+	}
+
+	var filter Filter
+	err := db.QueryRow(`
+		UPDATE filters
+		SET field = $1, op = $2, value = $3
+		FROM views
+		WHERE filters.view_id = views.id
+			AND filters.id = $4
+			AND views.owner_user_id = $5
+		RETURNING filters.id, filters.view_id, filters.field, filters.op, filters.value
+	`, req.Field, req.Op, req.Value, id, userID).Scan(&filter.ID, &filter.ViewID, &filter.Field, &filter.Op, &filter.Value)
+
+	if err == sql.ErrNoRows {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "Filter not found",
+		})
+	}
+
+	if err != nil {
+		log.Printf("Error updating filter: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to update filter",
+		})
+	}
+
+	return c.JSON(filter)
+}
+
+func deleteFilter(c *fiber.Ctx) error {
+	userID := auth.UserID(c)
+	id := c.Params("id")
+
+	result, err := db.Exec(`
+		DELETE FROM filters
+		USING views
+		WHERE filters.view_id = views.id
+			AND filters.id = $1
+			AND views.owner_user_id = $2
+	`, id, userID)
+	if err != nil {
+		log.Printf("Error deleting filter: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to delete filter",
+		})
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "Filter not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Filter deleted successfully",
+	})
+}
+
+func registerUser(c *fiber.Ctx) error {
+	var req UserReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if fieldErrs := validate.Struct(req); fieldErrs != nil {
+		return c.Status(422).JSON(fiber.Map{
+			"errors": fieldErrs,
+		})
+	}
+
+	passwordHash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		log.Printf("Error hashing password: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to register user",
+		})
+	}
+
+	var user User
+	err = db.QueryRow(`
+		INSERT INTO users (email, password_hash)
+		VALUES ($1, $2)
+		RETURNING id, email, created_at
+	`, req.Email, passwordHash).Scan(&user.ID, &user.Email, &user.CreatedAt)
+
+	if isUniqueViolation(err) {
+		return c.Status(409).JSON(fiber.Map{
+			"error": "Email is already registered",
+		})
+	}
+
+	if err != nil {
+		log.Printf("Error registering user: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to register user",
+		})
+	}
+
+	res, err := newAuthResponse(user)
+	if err != nil {
+		log.Printf("Error issuing tokens: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to register user",
+		})
+	}
+
+	return c.Status(201).JSON(res)
+}
+
+func loginUser(c *fiber.Ctx) error {
+	var req UserReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if fieldErrs := validate.Struct(req); fieldErrs != nil {
+		return c.Status(422).JSON(fiber.Map{
+			"errors": fieldErrs,
+		})
+	}
+
+	var user User
+	err := db.QueryRow(`
+		SELECT id, email, password_hash, created_at
+		FROM users
+		WHERE email = $1
+	`, req.Email).Scan(&user.ID, &user.Email, &user.PasswordHash, &user.CreatedAt)
+
+	if err == sql.ErrNoRows || (err == nil && !auth.CheckPassword(user.PasswordHash, req.Password)) {
+		return c.Status(401).JSON(fiber.Map{
+			"error": "Invalid email or password",
+		})
+	}
+
+	if err != nil {
+		log.Printf("Error looking up user: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to log in",
+		})
+	}
+
+	res, err := newAuthResponse(user)
+	if err != nil {
+		log.Printf("Error issuing tokens: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to log in",
+		})
+	}
+
+	return c.JSON(res)
+}
+
+func refreshToken(c *fiber.Ctx) error {
+	var req RefreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	claims, err := auth.Parse(jwtSecret, req.RefreshToken)
+	if err != nil || !claims.IsRefreshToken() {
+		return c.Status(401).JSON(fiber.Map{
+			"error": "Invalid or expired refresh token",
+		})
+	}
+
+	var user User
+	err = db.QueryRow(`
+		SELECT id, email, created_at
+		FROM users
+		WHERE id = $1
+	`, claims.UserID).Scan(&user.ID, &user.Email, &user.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return c.Status(401).JSON(fiber.Map{
+			"error": "Invalid or expired refresh token",
+		})
+	}
+
+	if err != nil {
+		log.Printf("Error looking up user: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to refresh token",
+		})
+	}
+
+	res, err := newAuthResponse(user)
+	if err != nil {
+		log.Printf("Error issuing tokens: %v", err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to refresh token",
+		})
+	}
+
+	return c.JSON(res)
+}
+
+func newAuthResponse(user User) (AuthResponse, error) {
+	accessToken, refreshTok, err := auth.IssueTokenPair(jwtSecret, user.ID)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+
+	return AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshTok,
+		User:         toUserRes(user),
+	}, nil
+}
+
+// isUniqueViolation reports whether err is a Postgres unique constraint
+// violation (SQLSTATE 23505), e.g. a duplicate email on registration.
+func isUniqueViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "23505")
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -265,6 +1245,17 @@ func main() {
 	}
 	defer db.Close()
 
+	// Initialize cache (non-fatal: degrades gracefully if Redis is down)
+	initCache()
+
+	// Initialize the realtime fan-out hub
+	initRealtime()
+
+	jwtSecret = getEnv("JWT_SECRET", "")
+	if jwtSecret == "" {
+		log.Fatal("JWT_SECRET must be set")
+	}
+
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
 		AppName:      "Neevs Backend API v1.0",
@@ -284,11 +1275,46 @@ func main() {
 	api := app.Group("/api")
 
 	api.Get("/health", healthCheck)
-	api.Get("/items", getItems)
-	api.Get("/items/:id", getItem)
-	api.Post("/items", createItem)
-	api.Put("/items/:id", updateItem)
-	api.Delete("/items/:id", deleteItem)
+
+	authGroup := api.Group("/auth")
+	authGroup.Post("/register", registerUser)
+	authGroup.Post("/login", loginUser)
+	authGroup.Post("/refresh", refreshToken)
+
+	itemsAuth := auth.AuthRequired(jwtSecret)
+	api.Get("/items", itemsAuth, httpcache.Conditional(func(c *fiber.Ctx) time.Time {
+		return itemsTracker.Collection(userScope(auth.UserID(c)))
+	}), getItems)
+	api.Get("/items/:id", itemsAuth, httpcache.Conditional(func(c *fiber.Ctx) time.Time {
+		return itemsTracker.Item(userScope(auth.UserID(c)), c.Params("id"))
+	}), getItem)
+	api.Post("/items", itemsAuth, createItem)
+	api.Put("/items/:id", itemsAuth, updateItem)
+	api.Delete("/items/:id", itemsAuth, deleteItem)
+
+	api.Post("/views", itemsAuth, createView)
+	api.Get("/views", itemsAuth, getViews)
+	api.Get("/views/:id", itemsAuth, getView)
+	api.Put("/views/:id", itemsAuth, updateView)
+	api.Delete("/views/:id", itemsAuth, deleteView)
+	api.Get("/views/:id/items", itemsAuth, getViewItems)
+	api.Post("/views/:id/filters", itemsAuth, createFilter)
+	api.Get("/views/:id/filters", itemsAuth, getFilters)
+	api.Put("/filters/:id", itemsAuth, updateFilter)
+	api.Delete("/filters/:id", itemsAuth, deleteFilter)
+
+	api.Use("/ws", itemsAuth, func(c *fiber.Ctx) error {
+		if websocket.IsWebSocketUpgrade(c) {
+			c.Locals("source", c.Query("source"))
+			return c.Next()
+		}
+		return fiber.ErrUpgradeRequired
+	})
+	api.Get("/ws", websocket.New(func(conn *websocket.Conn) {
+		userID, _ := conn.Locals("user_id").(int)
+		source, _ := conn.Locals("source").(string)
+		hub.Register(conn, userID, source)
+	}))
 
 	// Start server
 	port := getEnv("PORT", "3001")